@@ -0,0 +1,213 @@
+package vint64
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// AppendMany encodes each of vs, appending each encoded integer to
+// dst, and returns the extended buffer.
+//
+// Every run of [GroupSize] values is packed with [EncodeGroup]; a
+// trailing run shorter than GroupSize falls back to the scalar
+// [Encode] format. See [DecodeMany] for the corresponding decode
+// contract.
+func AppendMany(dst []byte, vs []uint64) []byte {
+	var g [MaxGroupLen]byte
+	i := 0
+	for ; i+GroupSize <= len(vs); i += GroupSize {
+		var group [GroupSize]uint64
+		copy(group[:], vs[i:i+GroupSize])
+		n := EncodeGroup(&g, group)
+		dst = append(dst, g[:n]...)
+	}
+	var b [MaxLen]byte
+	for ; i < len(vs); i++ {
+		// Encode only overwrites b[0] itself when the encoded form
+		// is shorter than MaxLen, so it must be cleared by hand
+		// before each reuse of the scratch buffer.
+		b[0] = 0
+		n := Encode(&b, vs[i])
+		dst = append(dst, b[:n]...)
+	}
+	return dst
+}
+
+// DecodeMany decodes a run of integers from src into dst, returning
+// the number of integers decoded and the number of bytes consumed
+// from src.
+//
+// DecodeMany mirrors AppendMany's layout: it decodes [GroupSize]
+// values at a time with [DecodeGroup] for as long as GroupSize more
+// values are still wanted, then falls back to scalar [Decode] for
+// the remainder. Because the choice between group and scalar
+// decoding is driven purely by how many values are still wanted,
+// not by a tag in the stream, len(dst) must match the number of
+// values originally passed to AppendMany for a full decode;
+// decoding into a dst longer than that count can misinterpret the
+// scalar-encoded tail as another group. A shorter dst reads a
+// correct prefix of the stream only when len(dst) is a multiple of
+// GroupSize or still covers every group in the stream; otherwise
+// the bytes of a group are decoded as scalar integers, which gives
+// wrong values or an error. It is not an error for src to run out
+// before dst is filled.
+//
+// DecodeMany stops and returns an error if src contains a partial or
+// otherwise invalid integer.
+func DecodeMany(dst []uint64, src []byte) (n int, consumed int, err error) {
+	for n+GroupSize <= len(dst) {
+		b := src[consumed:]
+		if len(b) == 0 {
+			return n, consumed, nil
+		}
+		vs, gn, err := DecodeGroup(b)
+		if err != nil {
+			return n, consumed, err
+		}
+		copy(dst[n:n+GroupSize], vs[:])
+		consumed += gn
+		n += GroupSize
+	}
+	for n < len(dst) {
+		b := src[consumed:]
+		if len(b) == 0 {
+			break
+		}
+		length := DecodedLen(b[0])
+		if length > len(b) {
+			return n, consumed, io.ErrUnexpectedEOF
+		}
+		v, err := Decode(b[:length])
+		if err != nil {
+			return n, consumed, err
+		}
+		dst[n] = v
+		consumed += length
+		n++
+	}
+	return n, consumed, nil
+}
+
+// ReadMany reads a run of integers from r into dst, returning the
+// number of integers read.
+//
+// ReadMany mirrors AppendMany's layout the same way [DecodeMany]
+// does, reading [GroupSize] values at a time for as long as
+// GroupSize more are still wanted before falling back to scalar
+// [Read]; see DecodeMany for why len(dst) must match the original
+// count for a grouped run to decode unambiguously.
+//
+// ReadMany stops at the first error, from r or from decoding. If r
+// returns [io.EOF] before any integer has been read, err is io.EOF;
+// if it returns io.EOF after at least one integer has been read, err
+// is [io.ErrUnexpectedEOF].
+func ReadMany(r io.ByteReader, dst []uint64) (n int, err error) {
+	for n+GroupSize <= len(dst) {
+		vs, err := readGroup(r)
+		if err != nil {
+			if n > 0 && err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return n, err
+		}
+		copy(dst[n:n+GroupSize], vs[:])
+		n += GroupSize
+	}
+	for n < len(dst) {
+		v, err := Read(r)
+		if err != nil {
+			if n > 0 && err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return n, err
+		}
+		dst[n] = v
+		n++
+	}
+	return n, nil
+}
+
+// readGroup reads a single [GroupSize] group, as written by
+// [EncodeGroup], from r.
+func readGroup(r io.ByteReader) (vs [GroupSize]uint64, err error) {
+	var hdr [2]byte
+	for i := range hdr {
+		hdr[i], err = r.ReadByte()
+		if err != nil {
+			return vs, err
+		}
+	}
+	desc := binary.LittleEndian.Uint16(hdr[:])
+	for i := 0; i < GroupSize; i++ {
+		l := int((desc>>(4*i))&0xf) + 1
+		if l > 8 {
+			return vs, ErrInvalidGroupDescriptor
+		}
+		var buf [8]byte
+		for j := 0; j < l; j++ {
+			buf[j], err = r.ReadByte()
+			if err != nil {
+				return vs, err
+			}
+		}
+		vs[i] = binary.LittleEndian.Uint64(buf[:])
+	}
+	return vs, nil
+}
+
+// AppendDeltas encodes the successive differences between vs,
+// appending the result to dst, and returns the extended buffer.
+//
+// The first value is encoded as-is. Each subsequent value is encoded
+// as the [Zigzag]-encoded difference from its predecessor, which
+// keeps the encoded size small for sequences of nearby values, such
+// as timestamps or monotonically increasing offsets, even when the
+// values themselves are large.
+func AppendDeltas(dst []byte, vs []uint64) []byte {
+	var b [MaxLen]byte
+	var prev uint64
+	for i, v := range vs {
+		d := v
+		if i > 0 {
+			d = Zigzag(int64(v - prev))
+		}
+		b[0] = 0
+		n := Encode(&b, d)
+		dst = append(dst, b[:n]...)
+		prev = v
+	}
+	return dst
+}
+
+// DecodeDeltas decodes a run of integers encoded by [AppendDeltas]
+// from src into dst, returning the number of integers decoded and
+// the number of bytes consumed from src.
+//
+// As with [DecodeMany], it is not an error for src to run out before
+// dst is filled.
+func DecodeDeltas(dst []uint64, src []byte) (n int, consumed int, err error) {
+	var prev uint64
+	for n < len(dst) {
+		b := src[consumed:]
+		if len(b) == 0 {
+			break
+		}
+		length := DecodedLen(b[0])
+		if length > len(b) {
+			return n, consumed, io.ErrUnexpectedEOF
+		}
+		d, err := Decode(b[:length])
+		if err != nil {
+			return n, consumed, err
+		}
+		v := d
+		if n > 0 {
+			v = prev + uint64(Unzigzag(d))
+		}
+		dst[n] = v
+		prev = v
+		consumed += length
+		n++
+	}
+	return n, consumed, nil
+}
@@ -0,0 +1,121 @@
+package vint64
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"testing"
+)
+
+func TestAppendDecodeMany(t *testing.T) {
+	for _, vs := range [][]uint64{
+		{0, 1, 0x0f0f, 0x0f0f_f0f0, math.MaxUint64},
+		{},
+		{1},
+		{1, 2, 3},
+		{1, 2, 3, 4},
+		{1, 2, 3, 4, 5},
+		{1, 2, 3, 4, 5, 6, 7, 8, 9},
+	} {
+		got := AppendMany(nil, vs)
+
+		dst := make([]uint64, len(vs))
+		n, consumed, err := DecodeMany(dst, got)
+		if err != nil {
+			t.Fatalf("%v: %v", vs, err)
+		}
+		if n != len(vs) {
+			t.Fatalf("%v: got %d, expected %d", vs, n, len(vs))
+		}
+		if consumed != len(got) {
+			t.Fatalf("%v: got %d, expected %d", vs, consumed, len(got))
+		}
+		for i, v := range vs {
+			if dst[i] != v {
+				t.Fatalf("%v: #%d: got %#x, expected %#x", vs, i, dst[i], v)
+			}
+		}
+	}
+}
+
+func TestDecodeManyShortDst(t *testing.T) {
+	vs := []uint64{1, 2, 3}
+	got := AppendMany(nil, vs)
+
+	dst := make([]uint64, 2)
+	n, consumed, err := DecodeMany(dst, got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Fatalf("got %d, expected %d", n, 2)
+	}
+	if consumed != EncodedLen(1)+EncodedLen(2) {
+		t.Fatalf("got %d, expected %d", consumed, EncodedLen(1)+EncodedLen(2))
+	}
+}
+
+func TestDecodeManyShortSrc(t *testing.T) {
+	var b [MaxLen]byte
+	n := Encode(&b, 0x0f0f_f0f0)
+	dst := make([]uint64, 2)
+	_, _, err := DecodeMany(dst, b[:n-1])
+	if err != io.ErrUnexpectedEOF {
+		t.Fatalf("got %v, expected %v", err, io.ErrUnexpectedEOF)
+	}
+}
+
+func TestReadMany(t *testing.T) {
+	vs := []uint64{1, 2, 3, 4}
+	got := AppendMany(nil, vs)
+
+	dst := make([]uint64, len(vs))
+	n, err := ReadMany(bytes.NewReader(got), dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != len(vs) {
+		t.Fatalf("got %d, expected %d", n, len(vs))
+	}
+	for i, v := range vs {
+		if dst[i] != v {
+			t.Fatalf("#%d: got %#x, expected %#x", i, dst[i], v)
+		}
+	}
+}
+
+func TestReadManyUnexpectedEOF(t *testing.T) {
+	var b [MaxLen]byte
+	n := Encode(&b, 1)
+	dst := make([]uint64, 2)
+	_, err := ReadMany(bytes.NewReader(b[:n]), dst)
+	if err != io.ErrUnexpectedEOF {
+		t.Fatalf("got %v, expected %v", err, io.ErrUnexpectedEOF)
+	}
+	_, err = ReadMany(bytes.NewReader(nil), dst[:1])
+	if err != io.EOF {
+		t.Fatalf("got %v, expected %v", err, io.EOF)
+	}
+}
+
+func TestAppendDecodeDeltas(t *testing.T) {
+	vs := []uint64{100, 101, 99, 1000, 1000, 0}
+	got := AppendDeltas(nil, vs)
+
+	dst := make([]uint64, len(vs))
+	n, consumed, err := DecodeDeltas(dst, got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != len(vs) {
+		t.Fatalf("got %d, expected %d", n, len(vs))
+	}
+	if consumed != len(got) {
+		t.Fatalf("got %d, expected %d", consumed, len(got))
+	}
+	for i, v := range vs {
+		if dst[i] != v {
+			t.Fatalf("#%d: got %#x, expected %#x", i, dst[i], v)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package vint64
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
+
+func TestEncodeDecodeGroup(t *testing.T) {
+	for i, vs := range [][GroupSize]uint64{
+		{0, 0, 0, 0},
+		{1, 2, 3, 4},
+		{0, math.MaxUint64, 1, 0x0f0f_f0f0},
+		{math.MaxUint64, math.MaxUint64, math.MaxUint64, math.MaxUint64},
+	} {
+		var b [MaxGroupLen]byte
+		n := EncodeGroup(&b, vs)
+		got := b[:n]
+
+		if a := AppendGroup(nil, vs); string(a) != string(got) {
+			t.Fatalf("#%d: got %#v, expected %#v", i, a, got)
+		}
+
+		length, err := DecodedLenGroup(got)
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if length != n {
+			t.Fatalf("#%d: got %d, expected %d", i, length, n)
+		}
+
+		dvs, dn, err := DecodeGroup(got)
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if dn != n || dvs != vs {
+			t.Fatalf("#%d: got (%v, %d), expected (%v, %d)", i, dvs, dn, vs, n)
+		}
+	}
+}
+
+func BenchmarkEncodeGroup(b *testing.B) {
+	rng := rand.New(rand.NewSource(seed()))
+	var s [1024][GroupSize]uint64
+	for i := range s {
+		for j := range s[i] {
+			s[i][j] = rng.Uint64()
+		}
+	}
+	var out [MaxGroupLen]byte
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		sink.int = EncodeGroup(&out, s[i%len(s)])
+	}
+}
+
+func BenchmarkEncodeGroupScalar(b *testing.B) {
+	rng := rand.New(rand.NewSource(seed()))
+	var s [1024][GroupSize]uint64
+	for i := range s {
+		for j := range s[i] {
+			s[i][j] = rng.Uint64()
+		}
+	}
+	var out [MaxLen]byte
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for _, v := range s[i%len(s)] {
+			sink.int = Encode(&out, v)
+		}
+	}
+}
+
+func BenchmarkDecodeGroup(b *testing.B) {
+	rng := rand.New(rand.NewSource(seed()))
+	var s [1024][MaxGroupLen]byte
+	for i := range s {
+		var vs [GroupSize]uint64
+		for j := range vs {
+			vs[j] = rng.Uint64()
+		}
+		EncodeGroup(&s[i], vs)
+	}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		vs, _, err := DecodeGroup(s[i%len(s)][:])
+		if err != nil {
+			b.Fatal(err)
+		}
+		sink.uint64 = vs[0]
+	}
+}
+
+func BenchmarkDecodeGroupScalar(b *testing.B) {
+	rng := rand.New(rand.NewSource(seed()))
+	var s [1024][]byte
+	for i := range s {
+		var buf [MaxLen]byte
+		for j := 0; j < GroupSize; j++ {
+			buf[0] = 0
+			n := Encode(&buf, rng.Uint64())
+			s[i] = append(s[i], buf[:n]...)
+		}
+	}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		var vs [GroupSize]uint64
+		off := 0
+		for j := 0; j < GroupSize; j++ {
+			v, err := Decode(s[i%len(s)][off:])
+			if err != nil {
+				b.Fatal(err)
+			}
+			vs[j] = v
+			off += EncodedLen(v)
+		}
+		sink.uint64 = vs[0]
+	}
+}
@@ -0,0 +1,118 @@
+package vint64
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// Kind identifies which varint encoding a byte slice holds, as
+// reported by [DecodeAny].
+type Kind int
+
+const (
+	// KindVint64 is the encoding implemented by this package.
+	KindVint64 Kind = iota
+	// KindStdVarint is the LEB128-style encoding used by
+	// [encoding/binary]'s Uvarint and Varint.
+	KindStdVarint
+)
+
+// String implements [fmt.Stringer].
+func (k Kind) String() string {
+	switch k {
+	case KindVint64:
+		return "vint64"
+	case KindStdVarint:
+		return "stdvarint"
+	default:
+		return "unknown"
+	}
+}
+
+// ErrStdVarintOverflow is returned by [DecodeStdVarint] when the
+// encoded value overflows 64 bits.
+var ErrStdVarintOverflow = errors.New("vint: stdvarint overflows uint64")
+
+// AppendStdVarint encodes v using the same format as
+// [encoding/binary]'s Uvarint, appending the result to dst, and
+// returns the extended buffer.
+func AppendStdVarint(dst []byte, v uint64) []byte {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(b[:], v)
+	return append(dst, b[:n]...)
+}
+
+// DecodeStdVarint decodes an [encoding/binary] Uvarint-encoded
+// integer from the front of src, returning the value and the
+// number of bytes consumed.
+func DecodeStdVarint(src []byte) (v uint64, n int, err error) {
+	v, n = binary.Uvarint(src)
+	if n == 0 {
+		return 0, 0, io.ErrUnexpectedEOF
+	}
+	if n < 0 {
+		return 0, -n, ErrStdVarintOverflow
+	}
+	return v, n, nil
+}
+
+// ConvertFromStdUvarint reads a single [encoding/binary] Uvarint
+// from the front of src, re-encodes it in vint64 form, and appends
+// the result to dst. It returns the extended buffer and the number
+// of bytes consumed from src.
+//
+// ConvertFromStdUvarint is intended for migrating on-disk data
+// written with encoding/binary to this package's encoding, one
+// integer at a time, without a temporary heap allocation per value.
+func ConvertFromStdUvarint(dst []byte, src []byte) (out []byte, consumed int, err error) {
+	v, n, err := DecodeStdVarint(src)
+	if err != nil {
+		return dst, n, err
+	}
+	var b [MaxLen]byte
+	m := Encode(&b, v)
+	return append(dst, b[:m]...), n, nil
+}
+
+// ConvertToStdUvarint reads a single vint64-encoded integer from
+// the front of src, re-encodes it as an [encoding/binary] Uvarint,
+// and appends the result to dst. It returns the extended buffer and
+// the number of bytes consumed from src.
+func ConvertToStdUvarint(dst []byte, src []byte) (out []byte, consumed int, err error) {
+	if len(src) == 0 {
+		return dst, 0, io.ErrUnexpectedEOF
+	}
+	n := DecodedLen(src[0])
+	if n > len(src) {
+		return dst, 0, io.ErrUnexpectedEOF
+	}
+	v, err := Decode(src[:n])
+	if err != nil {
+		return dst, 0, err
+	}
+	return AppendStdVarint(dst, v), n, nil
+}
+
+// DecodeAny decodes a single integer from the front of b, sniffing
+// whether it is encoded as vint64 or as an encoding/binary Uvarint.
+//
+// Because the two formats' bit patterns overlap, this is a
+// heuristic, not a proof: b is first interpreted as vint64, since
+// that encoding is canonical and rejects many inputs (e.g. any
+// value with unnecessary leading zeros) that a Uvarint decoder would
+// happily accept; only if that interpretation fails does DecodeAny
+// fall back to treating b as a Uvarint. Callers that already know
+// which format a given byte stream uses should call [Decode] or
+// [DecodeStdVarint] directly instead of relying on this heuristic.
+func DecodeAny(b []byte) (v uint64, n int, kind Kind, err error) {
+	if len(b) > 0 {
+		if ln := DecodedLen(b[0]); ln <= len(b) {
+			if dv, derr := Decode(b[:ln]); derr == nil {
+				return dv, ln, KindVint64, nil
+			}
+		}
+	}
+	v, n, err = DecodeStdVarint(b)
+	return v, n, KindStdVarint, err
+}
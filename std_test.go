@@ -0,0 +1,103 @@
+package vint64
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+func TestStdVarintRoundTrip(t *testing.T) {
+	for _, v := range []uint64{0, 1, 0x0f0f, 0x0f0f_f0f0, math.MaxUint64} {
+		got := AppendStdVarint(nil, v)
+		want := binary.AppendUvarint(nil, v)
+		if !bytes.Equal(got, want) {
+			t.Fatalf("%#x: got %#v, expected %#v", v, got, want)
+		}
+		dv, n, err := DecodeStdVarint(got)
+		if err != nil {
+			t.Fatalf("%#x: %v", v, err)
+		}
+		if n != len(got) || dv != v {
+			t.Fatalf("%#x: got (%#x, %d), expected (%#x, %d)", v, dv, n, v, len(got))
+		}
+	}
+}
+
+func TestConvertStdUvarint(t *testing.T) {
+	for _, v := range []uint64{0, 1, 0x0f0f, 0x0f0f_f0f0, math.MaxUint64} {
+		std := binary.AppendUvarint(nil, v)
+
+		vi, consumed, err := ConvertFromStdUvarint(nil, std)
+		if err != nil {
+			t.Fatalf("%#x: %v", v, err)
+		}
+		if consumed != len(std) {
+			t.Fatalf("%#x: got %d, expected %d", v, consumed, len(std))
+		}
+		dv, err := Decode(vi)
+		if err != nil || dv != v {
+			t.Fatalf("%#x: got (%#x, %v)", v, dv, err)
+		}
+
+		back, consumed, err := ConvertToStdUvarint(nil, vi)
+		if err != nil {
+			t.Fatalf("%#x: %v", v, err)
+		}
+		if consumed != len(vi) || !bytes.Equal(back, std) {
+			t.Fatalf("%#x: got (%#v, %d), expected (%#v, %d)", v, back, consumed, std, len(vi))
+		}
+	}
+}
+
+func TestDecodeAny(t *testing.T) {
+	const v = 0x0f0f_f0f0
+	var b [MaxLen]byte
+	n := Encode(&b, v)
+	vi := b[:n]
+	_, _, kind, err := DecodeAny(vi)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kind != KindVint64 {
+		t.Fatalf("got %v, expected %v", kind, KindVint64)
+	}
+
+	// 300 needs a 2-byte Uvarint, and its first byte (0xac) is a
+	// continuation byte that DecodedLen would read as needing a
+	// 3-byte vint64 encoding; the 2-byte slice is too short for
+	// that, so Decode fails and DecodeAny falls back to Uvarint.
+	// This does not make the two formats unambiguous in general —
+	// see TestDecodeAnyAmbiguous below.
+	std := binary.AppendUvarint(nil, 300)
+	dv, n, kind, err := DecodeAny(std)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kind != KindStdVarint {
+		t.Fatalf("got %v, expected %v", kind, KindStdVarint)
+	}
+	if dv != 300 || n != len(std) {
+		t.Fatalf("got (%#x, %d), expected (%#x, %d)", dv, n, 300, len(std))
+	}
+}
+
+// TestDecodeAnyAmbiguous pins down DecodeAny's resolution order for
+// a byte that is simultaneously a valid, complete encoding under
+// both formats: 0x03 is a 1-byte vint64 encoding of 1 (zero trailing
+// zero bits means n == 0) and a 1-byte Uvarint encoding of 3 (high
+// bit clear). DecodeAny's doc comment says vint64 is tried first, so
+// it must report the vint64 interpretation here, not the Uvarint one.
+func TestDecodeAnyAmbiguous(t *testing.T) {
+	b := []byte{0x03}
+	v, n, kind, err := DecodeAny(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kind != KindVint64 {
+		t.Fatalf("got %v, expected %v", kind, KindVint64)
+	}
+	if v != 1 || n != 1 {
+		t.Fatalf("got (%#x, %d), expected (%#x, %d)", v, n, 1, 1)
+	}
+}
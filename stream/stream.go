@@ -0,0 +1,138 @@
+// Package stream implements a length-prefixed, checksummed framing
+// format built on top of [vint64], loosely modeled on the framing
+// format described by the [Snappy framing format].
+//
+// Each frame consists of a vint64-encoded payload length, a CRC32C
+// (Castagnoli) checksum of the payload, and the payload itself:
+//
+//	+-------------------+------------------+----------------+
+//	| length (vint64)    | checksum (4B LE) | payload (n B)  |
+//	+-------------------+------------------+----------------+
+//
+// [Snappy framing format]: https://github.com/google/snappy/blob/main/framing_format.txt
+package stream
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+
+	"github.com/ericlagergren/vint64"
+)
+
+// DefaultMaxFrameSize is the maximum payload size accepted by a
+// [Reader] unless overridden with [Reader.SetMaxFrameSize].
+const DefaultMaxFrameSize = 16 << 20 // 16 MiB
+
+// checksumSize is the size in bytes of a frame's CRC32C checksum.
+const checksumSize = 4
+
+// ErrChecksumMismatch is returned by [Reader.ReadFrame] when a
+// frame's payload does not match its checksum.
+var ErrChecksumMismatch = errors.New("stream: checksum mismatch")
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// Writer writes a sequence of checksummed, length-prefixed frames.
+type Writer struct {
+	w   io.Writer
+	hdr [vint64.MaxLen]byte // length prefix scratch
+	val [vint64.MaxLen]byte // WriteUint64 payload scratch
+}
+
+// NewWriter returns a [Writer] that writes frames to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// WriteFrame writes p as a single frame.
+func (w *Writer) WriteFrame(p []byte) error {
+	w.hdr[0] = 0
+	n := vint64.Encode(&w.hdr, uint64(len(p)))
+	if _, err := w.w.Write(w.hdr[:n]); err != nil {
+		return err
+	}
+	var sum [checksumSize]byte
+	binary.LittleEndian.PutUint32(sum[:], crc32.Checksum(p, castagnoli))
+	if _, err := w.w.Write(sum[:]); err != nil {
+		return err
+	}
+	_, err := w.w.Write(p)
+	return err
+}
+
+// WriteUint64 writes v as a single frame containing its vint64
+// encoding.
+func (w *Writer) WriteUint64(v uint64) error {
+	w.val[0] = 0
+	n := vint64.Encode(&w.val, v)
+	return w.WriteFrame(w.val[:n])
+}
+
+// Reader reads a sequence of frames written by a [Writer].
+//
+// A Reader reuses its internal buffers across calls to [Reader.ReadFrame],
+// so the returned slice is only valid until the next call.
+type Reader struct {
+	r       *bufio.Reader
+	max     int
+	scratch []byte
+}
+
+// NewReader returns a [Reader] that reads frames from r.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{
+		r:   bufio.NewReader(r),
+		max: DefaultMaxFrameSize,
+	}
+}
+
+// SetMaxFrameSize sets the largest payload size the [Reader] will
+// accept. ReadFrame and ReadUint64 reject any frame whose declared
+// length exceeds n, which guards against hostile or corrupt input
+// causing an unbounded allocation.
+func (r *Reader) SetMaxFrameSize(n int) {
+	r.max = n
+}
+
+// ReadFrame reads and returns the next frame's payload.
+//
+// The returned slice is backed by memory owned by r and is only
+// valid until the next call to ReadFrame or ReadUint64.
+func (r *Reader) ReadFrame() ([]byte, error) {
+	size, err := vint64.Read(r.r)
+	if err != nil {
+		return nil, err
+	}
+	if size > uint64(r.max) {
+		return nil, fmt.Errorf("stream: frame size %d exceeds maximum %d", size, r.max)
+	}
+	n := checksumSize + int(size)
+	if cap(r.scratch) < n {
+		r.scratch = make([]byte, n)
+	} else {
+		r.scratch = r.scratch[:n]
+	}
+	if _, err := io.ReadFull(r.r, r.scratch); err != nil {
+		return nil, err
+	}
+	sum := binary.LittleEndian.Uint32(r.scratch[:checksumSize])
+	payload := r.scratch[checksumSize:]
+	if crc32.Checksum(payload, castagnoli) != sum {
+		return nil, ErrChecksumMismatch
+	}
+	return payload, nil
+}
+
+// ReadUint64 reads the next frame and decodes its payload as a
+// single vint64-encoded integer.
+func (r *Reader) ReadUint64() (uint64, error) {
+	p, err := r.ReadFrame()
+	if err != nil {
+		return 0, err
+	}
+	return vint64.Decode(p)
+}
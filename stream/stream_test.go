@@ -0,0 +1,86 @@
+package stream
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteReadFrame(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+
+	frames := [][]byte{
+		[]byte(""),
+		[]byte("hello"),
+		bytes.Repeat([]byte{0x42}, 300),
+	}
+	for _, f := range frames {
+		if err := w.WriteFrame(f); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := NewReader(&buf)
+	for i, want := range frames {
+		got, err := r.ReadFrame()
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Fatalf("#%d: got %#v, expected %#v", i, got, want)
+		}
+	}
+}
+
+func TestWriteReadUint64(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+
+	vs := []uint64{0, 1, 0x0f0f, 0x0f0f_f0f0_0f0f_f0f0}
+	for _, v := range vs {
+		if err := w.WriteUint64(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := NewReader(&buf)
+	for i, want := range vs {
+		got, err := r.ReadUint64()
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if got != want {
+			t.Fatalf("#%d: got %#x, expected %#x", i, got, want)
+		}
+	}
+}
+
+func TestReadFrameChecksumMismatch(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	if err := w.WriteFrame([]byte("hello")); err != nil {
+		t.Fatal(err)
+	}
+
+	b := buf.Bytes()
+	b[len(b)-1] ^= 0xff // corrupt the payload without touching the checksum
+
+	r := NewReader(bytes.NewReader(b))
+	if _, err := r.ReadFrame(); err != ErrChecksumMismatch {
+		t.Fatalf("got %v, expected %v", err, ErrChecksumMismatch)
+	}
+}
+
+func TestReadFrameMaxSize(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	if err := w.WriteFrame(make([]byte, 100)); err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewReader(&buf)
+	r.SetMaxFrameSize(10)
+	if _, err := r.ReadFrame(); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -77,8 +77,12 @@ func Decode(b []byte) (v uint64, err error) {
 	if n >= 8 {
 		v = binary.LittleEndian.Uint64(b[1:])
 	} else {
+		// Only copy the n+1 bytes that actually belong to this
+		// integer: b may be longer than the encoded integer (e.g.
+		// followed by payload data), and copying the rest of b
+		// would pull that trailing data into v.
 		e := make([]byte, 8)
-		copy(e, b)
+		copy(e, b[:n+1])
 		v = binary.LittleEndian.Uint64(e) >> (n + 1)
 	}
 	if n != 0 && v < 1<<(7*n) {
@@ -114,6 +118,16 @@ func Encode(b *[MaxLen]byte, v uint64) int {
 	return int(n + 1)
 }
 
+// Append encodes v, appending the result to dst, and returns the
+// extended buffer.
+//
+// To encode a signed integer, convert the input with [Zigzag].
+func Append(dst []byte, v uint64) []byte {
+	var b [MaxLen]byte
+	n := Encode(&b, v)
+	return append(dst, b[:n]...)
+}
+
 // EncodedLen returns the number of bytes necessary to encode v.
 //
 // The result will always be in [0, 9].
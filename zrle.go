@@ -0,0 +1,139 @@
+package vint64
+
+import (
+	"errors"
+	"io"
+	"math"
+)
+
+// ErrShortZRLEBuffer is returned by [DecodeZRLE] when dst is too
+// small to hold an entire decoded zero run. DecodeZRLE expands a run
+// atomically, so it cannot stop partway through one the way it can
+// stop between ordinary values.
+var ErrShortZRLEBuffer = errors.New("vint: dst too small for zero run")
+
+// ErrZRLEOverflow is returned by [AppendZRLE] and [EncodedLenZRLE]
+// when vs contains math.MaxUint64, which the zero-run-length
+// transform cannot represent: shifting it by one to make room for
+// the zero marker wraps it around to 0, the marker value itself,
+// which would desync the rest of the stream.
+var ErrZRLEOverflow = errors.New("vint: zrle cannot encode math.MaxUint64")
+
+// AppendZRLE applies a zero-run-length transform to vs before
+// vint64-encoding it, appending the result to dst, and returns the
+// extended buffer.
+//
+// Runs of consecutive zeros are collapsed into a marker (the
+// encoding of 0) followed by the run length; every other value is
+// encoded as itself plus one, which frees up 0 to serve as the
+// marker. This is a good fit for vectors of small deltas, bitmap
+// positions, or sparse histograms, where long runs of zeros
+// dominate: a run of n zeros costs EncodedLen(n)+1 bytes instead of
+// n bytes.
+//
+// The transform is invertible by [DecodeZRLE] for every value
+// except math.MaxUint64, whose shifted form wraps around to the
+// marker; AppendZRLE reports that case as [ErrZRLEOverflow] instead
+// of silently emitting a stream that would desync on decode.
+func AppendZRLE(dst []byte, vs []uint64) ([]byte, error) {
+	var b [MaxLen]byte
+	encode := func(v uint64) {
+		b[0] = 0
+		n := Encode(&b, v)
+		dst = append(dst, b[:n]...)
+	}
+	for i := 0; i < len(vs); {
+		if vs[i] != 0 {
+			if vs[i] == math.MaxUint64 {
+				return dst, ErrZRLEOverflow
+			}
+			encode(vs[i] + 1)
+			i++
+			continue
+		}
+		j := i
+		for j < len(vs) && vs[j] == 0 {
+			j++
+		}
+		encode(0)
+		encode(uint64(j - i))
+		i = j
+	}
+	return dst, nil
+}
+
+// EncodedLenZRLE returns the number of bytes [AppendZRLE] would
+// append for vs, or [ErrZRLEOverflow] if vs contains math.MaxUint64.
+func EncodedLenZRLE(vs []uint64) (int, error) {
+	n := 0
+	for i := 0; i < len(vs); {
+		if vs[i] != 0 {
+			if vs[i] == math.MaxUint64 {
+				return 0, ErrZRLEOverflow
+			}
+			n += EncodedLen(vs[i] + 1)
+			i++
+			continue
+		}
+		j := i
+		for j < len(vs) && vs[j] == 0 {
+			j++
+		}
+		n += EncodedLen(0) + EncodedLen(uint64(j-i))
+		i = j
+	}
+	return n, nil
+}
+
+// DecodeZRLE decodes a run of integers encoded by [AppendZRLE] from
+// src into dst, returning the number of integers decoded and the
+// number of bytes consumed from src.
+//
+// As with [DecodeMany], it is not an error for src to run out
+// before dst is filled. It is, however, an error for a zero run to
+// only partially fit in the remaining space of dst; see
+// [ErrShortZRLEBuffer].
+func DecodeZRLE(dst []uint64, src []byte) (n int, consumed int, err error) {
+	readOne := func() (uint64, error) {
+		b := src[consumed:]
+		length := DecodedLen(b[0])
+		if length > len(b) {
+			return 0, io.ErrUnexpectedEOF
+		}
+		v, err := Decode(b[:length])
+		if err != nil {
+			return 0, err
+		}
+		consumed += length
+		return v, nil
+	}
+	for n < len(dst) {
+		if len(src) == consumed {
+			break
+		}
+		tok, err := readOne()
+		if err != nil {
+			return n, consumed, err
+		}
+		if tok != 0 {
+			dst[n] = tok - 1
+			n++
+			continue
+		}
+		if len(src) == consumed {
+			return n, consumed, io.ErrUnexpectedEOF
+		}
+		run, err := readOne()
+		if err != nil {
+			return n, consumed, err
+		}
+		if run > uint64(len(dst)-n) {
+			return n, consumed, ErrShortZRLEBuffer
+		}
+		for i := uint64(0); i < run; i++ {
+			dst[n] = 0
+			n++
+		}
+	}
+	return n, consumed, nil
+}
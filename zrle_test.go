@@ -0,0 +1,75 @@
+package vint64
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAppendDecodeZRLE(t *testing.T) {
+	for _, vs := range [][]uint64{
+		{},
+		{0},
+		{0, 0, 0, 0, 0},
+		{1, 2, 3},
+		{0, 0, 1, 0, 0, 0, 2, 0},
+		{5, 0, 0, 0, 0, 0, 0, 0, 0, 0, 6},
+	} {
+		got, err := AppendZRLE(nil, vs)
+		if err != nil {
+			t.Fatalf("%v: %v", vs, err)
+		}
+		if n, err := EncodedLenZRLE(vs); err != nil || n != len(got) {
+			t.Fatalf("%v: EncodedLenZRLE got (%d, %v), expected %d", vs, n, err, len(got))
+		}
+
+		dst := make([]uint64, len(vs))
+		n, consumed, err := DecodeZRLE(dst, got)
+		if err != nil {
+			t.Fatalf("%v: %v", vs, err)
+		}
+		if n != len(vs) || consumed != len(got) {
+			t.Fatalf("%v: got (%d, %d), expected (%d, %d)", vs, n, consumed, len(vs), len(got))
+		}
+		for i, v := range vs {
+			if dst[i] != v {
+				t.Fatalf("%v: #%d: got %#x, expected %#x", vs, i, dst[i], v)
+			}
+		}
+	}
+}
+
+func TestAppendZRLEOverflow(t *testing.T) {
+	if _, err := AppendZRLE(nil, []uint64{1, math.MaxUint64, 2}); err != ErrZRLEOverflow {
+		t.Fatalf("got %v, expected %v", err, ErrZRLEOverflow)
+	}
+	if _, err := EncodedLenZRLE([]uint64{1, math.MaxUint64, 2}); err != ErrZRLEOverflow {
+		t.Fatalf("got %v, expected %v", err, ErrZRLEOverflow)
+	}
+}
+
+func TestDecodeZRLEShortBuffer(t *testing.T) {
+	got, err := AppendZRLE(nil, []uint64{0, 0, 0, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dst := make([]uint64, 2)
+	if _, _, err := DecodeZRLE(dst, got); err != ErrShortZRLEBuffer {
+		t.Fatalf("got %v, expected %v", err, ErrShortZRLEBuffer)
+	}
+}
+
+func TestDecodeZRLEPartialDst(t *testing.T) {
+	vs := []uint64{1, 2, 3, 4}
+	got, err := AppendZRLE(nil, vs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dst := make([]uint64, 2)
+	n, consumed, err := DecodeZRLE(dst, got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 || consumed != EncodedLen(2)+EncodedLen(3) {
+		t.Fatalf("got (%d, %d), expected (%d, %d)", n, consumed, 2, EncodedLen(2)+EncodedLen(3))
+	}
+}